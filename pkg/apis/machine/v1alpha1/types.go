@@ -0,0 +1,146 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 is the v1alpha1 version of the machine-controller-manager API.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/machine-controller-manager/pkg/util/conditions"
+)
+
+// ClassSpec identifies the MachineClass (of a given provider Kind) that a Machine/MachineSet/
+// MachineDeployment was created from.
+type ClassSpec struct {
+	// Kind of the MachineClass, e.g. "AzureMachineClass".
+	Kind string `json:"kind,omitempty"`
+	// Name of the MachineClass.
+	Name string `json:"name,omitempty"`
+}
+
+// MachineSpec is the desired state of a Machine.
+type MachineSpec struct {
+	// Class is the MachineClass this Machine was provisioned from.
+	Class ClassSpec `json:"class,omitempty"`
+	// ProviderID is the provider-assigned ID of the backing VM, in the form
+	// <ProviderName>://<ProviderSpecificID>.
+	ProviderID string `json:"providerID,omitempty"`
+}
+
+// MachineStatus is the observed state of a Machine.
+type MachineStatus struct {
+	// Node is the name of the Kubernetes Node object backed by this Machine, once joined.
+	Node string `json:"node,omitempty"`
+	// Conditions track the state of this Machine. See conditions.Condition for the shared
+	// Set/Get/MarkTrue/MarkFalse implementation used by every controller in this repo.
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Machine is the representation of a physical or virtual machine managed by this controller.
+type Machine struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MachineSpec   `json:"spec,omitempty"`
+	Status MachineStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MachineList is a list of Machines.
+type MachineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Machine `json:"items"`
+}
+
+// MachineTemplateSpec describes the Machines a MachineSet/MachineDeployment should create.
+type MachineTemplateSpec struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              MachineSpec `json:"spec,omitempty"`
+}
+
+// MachineSetSpec is the desired state of a MachineSet.
+type MachineSetSpec struct {
+	Replicas int32                 `json:"replicas,omitempty"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	Template MachineTemplateSpec   `json:"template,omitempty"`
+}
+
+// MachineSetStatus is the observed state of a MachineSet.
+type MachineSetStatus struct {
+	Replicas   int32                  `json:"replicas,omitempty"`
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MachineSet ensures a fixed number of Machines created from the same template are running.
+type MachineSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MachineSetSpec   `json:"spec,omitempty"`
+	Status MachineSetStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MachineSetList is a list of MachineSets.
+type MachineSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []MachineSet `json:"items"`
+}
+
+// MachineDeploymentSpec is the desired state of a MachineDeployment.
+type MachineDeploymentSpec struct {
+	Replicas int32                 `json:"replicas,omitempty"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	Template MachineTemplateSpec   `json:"template,omitempty"`
+}
+
+// MachineDeploymentStatus is the observed state of a MachineDeployment.
+type MachineDeploymentStatus struct {
+	Replicas   int32                  `json:"replicas,omitempty"`
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MachineDeployment provides declarative updates for MachineSets and the Machines they own.
+type MachineDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MachineDeploymentSpec   `json:"spec,omitempty"`
+	Status MachineDeploymentStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MachineDeploymentList is a list of MachineDeployments.
+type MachineDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []MachineDeployment `json:"items"`
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/machine-controller-manager/pkg/util/conditions"
+)
+
+// AWSMachineClassSpec is the provider-specific configuration to create a Machine on AWS.
+type AWSMachineClassSpec struct {
+	Region string `json:"region,omitempty"`
+	AMI    string `json:"ami,omitempty"`
+}
+
+// AWSMachineClassStatus is the observed state of an AWSMachineClass.
+type AWSMachineClassStatus struct {
+	// Conditions track why the class can or cannot be deleted. See
+	// ClassValidated/ClassInUse/FinalizerRemovalBlocked/SelfDeletionBlocked in pkg/controller.
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AWSMachineClass is a provider-specific configuration for creating Machines on AWS.
+type AWSMachineClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AWSMachineClassSpec   `json:"spec,omitempty"`
+	Status AWSMachineClassStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AWSMachineClassList is a list of AWSMachineClasses.
+type AWSMachineClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []AWSMachineClass `json:"items"`
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/machine-controller-manager/pkg/util/conditions"
+)
+
+// OpenStackMachineClassSpec is the provider-specific configuration to create a Machine on OpenStack.
+type OpenStackMachineClassSpec struct {
+	Region    string `json:"region,omitempty"`
+	ImageName string `json:"imageName,omitempty"`
+}
+
+// OpenStackMachineClassStatus is the observed state of an OpenStackMachineClass.
+type OpenStackMachineClassStatus struct {
+	// Conditions track why the class can or cannot be deleted. See
+	// ClassValidated/ClassInUse/FinalizerRemovalBlocked/SelfDeletionBlocked in pkg/controller.
+	Conditions []conditions.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OpenStackMachineClass is a provider-specific configuration for creating Machines on OpenStack.
+type OpenStackMachineClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenStackMachineClassSpec   `json:"spec,omitempty"`
+	Status OpenStackMachineClassStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OpenStackMachineClassList is a list of OpenStackMachineClasses.
+type OpenStackMachineClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []OpenStackMachineClass `json:"items"`
+}
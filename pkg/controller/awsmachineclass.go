@@ -0,0 +1,270 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/api"
+
+	"github.com/golang/glog"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/validation"
+	"github.com/gardener/machine-controller-manager/pkg/util/conditions"
+	"github.com/gardener/machine-controller-manager/pkg/util/finalizers"
+)
+
+// AWSMachineClassKind is used to identify the machineClassKind as AWS
+const AWSMachineClassKind = "AWSMachineClass"
+
+func (c *controller) machineDeploymentToAWSMachineClassDelete(obj interface{}) {
+	machineDeployment, ok := obj.(*v1alpha1.MachineDeployment)
+	if machineDeployment == nil || !ok {
+		return
+	}
+	if machineDeployment.Spec.Template.Spec.Class.Kind == AWSMachineClassKind {
+		c.awsMachineClassQueue.Add(machineDeployment.Spec.Template.Spec.Class.Name)
+	}
+}
+
+func (c *controller) machineSetToAWSMachineClassDelete(obj interface{}) {
+	machineSet, ok := obj.(*v1alpha1.MachineSet)
+	if machineSet == nil || !ok {
+		return
+	}
+	if machineSet.Spec.Template.Spec.Class.Kind == AWSMachineClassKind {
+		c.awsMachineClassQueue.Add(machineSet.Spec.Template.Spec.Class.Name)
+	}
+}
+
+func (c *controller) machineToAWSMachineClassDelete(obj interface{}) {
+	machine, ok := obj.(*v1alpha1.Machine)
+	if machine == nil || !ok {
+		return
+	}
+	if machine.Spec.Class.Kind == AWSMachineClassKind {
+		c.awsMachineClassQueue.Add(machine.Spec.Class.Name)
+	}
+}
+
+func (c *controller) awsMachineClassAdd(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		glog.Errorf("Couldn't get key for object %+v: %v", obj, err)
+		return
+	}
+	c.awsMachineClassQueue.Add(key)
+}
+
+func (c *controller) awsMachineClassUpdate(oldObj, newObj interface{}) {
+	old, ok := oldObj.(*v1alpha1.AWSMachineClass)
+	if old == nil || !ok {
+		return
+	}
+	new, ok := oldObj.(*v1alpha1.AWSMachineClass)
+	if new == nil || !ok {
+		return
+	}
+
+	c.awsMachineClassAdd(newObj)
+}
+
+// reconcileClusterAWSMachineClassKey reconciles an AWSMachineClass due to controller resync or an
+// event on the awsMachineClass.
+func (c *controller) reconcileClusterAWSMachineClassKey(key string) error {
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	class, err := c.awsMachineClassLister.AWSMachineClasses(c.namespace).Get(name)
+
+	if errors.IsNotFound(err) {
+		glog.Infof("%s %q: Not doing work because it has been deleted", AWSMachineClassKind, key)
+		return nil
+	}
+	if err != nil {
+		glog.Infof("%s %q: Unable to retrieve object from store: %v", AWSMachineClassKind, key, err)
+		return err
+	}
+
+	return c.reconcileClusterAWSMachineClass(class)
+}
+
+func (c *controller) reconcileClusterAWSMachineClass(class *v1alpha1.AWSMachineClass) error {
+	// Finalizer handling always runs first, ahead of validation and any other work, so a
+	// finalizer stripped mid-lifecycle is restored regardless of whether the rest of the
+	// reconcile succeeds.
+	if class.DeletionTimestamp == nil {
+		if _, err := finalizers.EnsureFinalizer(c.getAWSMachineClass(class.Namespace), c.patchAWSMachineClass(class.Namespace), class.Name, DeleteFinalizerName); err != nil {
+			return err
+		}
+	}
+
+	classConditions := &awsMachineClassConditions{class: class}
+
+	internalClass := &machine.AWSMachineClass{}
+	err := api.Scheme.Convert(class, internalClass, nil)
+	if err != nil {
+		return err
+	}
+	// TODO this should be put in own API server
+	validationerr := validation.ValidateAWSMachineClass(internalClass)
+	if validationerr.ToAggregate() != nil && len(validationerr.ToAggregate().Errors()) > 0 {
+		glog.V(2).Infof("Validation of %s failed %s", AWSMachineClassKind, validationerr.ToAggregate().Error())
+		conditions.MarkFalse(classConditions, ClassValidated, ReasonValidationFailed, validationerr.ToAggregate().Error())
+		return c.updateAWSMachineClassConditions(class)
+	}
+	conditions.MarkTrue(classConditions, ClassValidated)
+
+	machines, err := c.findMachinesForClass(AWSMachineClassKind, class.Name)
+	if err != nil {
+		return err
+	}
+	machineSets, err := c.findMachineSetsForClass(AWSMachineClassKind, class.Name)
+	if err != nil {
+		return err
+	}
+	machineDeployments, err := c.findMachineDeploymentsForClass(AWSMachineClassKind, class.Name)
+	if err != nil {
+		return err
+	}
+
+	inUseStatus := conditions.ConditionFalse
+	if len(machineDeployments) > 0 || len(machineSets) > 0 || len(machines) > 0 {
+		inUseStatus = conditions.ConditionTrue
+	}
+	conditions.Set(classConditions, conditions.Condition{
+		Type:    ClassInUse,
+		Status:  inUseStatus,
+		Message: fmt.Sprintf("%d MachineDeployment(s), %d MachineSet(s), %d Machine(s) reference this class", len(machineDeployments), len(machineSets), len(machines)),
+	})
+	// A failed condition write must not block the cascading-deletion/finalizer handling below -
+	// that is the functional half of reconcile, and a status subresource hiccup shouldn't be
+	// able to deadlock it.
+	if err := c.updateAWSMachineClassConditions(class); err != nil {
+		glog.Errorf("Failed to update %s %q conditions: %v", AWSMachineClassKind, class.Name, err)
+	}
+
+	if class.DeletionTimestamp != nil {
+		if finalizerSet := sets.NewString(class.Finalizers...); !finalizerSet.Has(DeleteFinalizerName) {
+			return nil
+		}
+
+		if len(machineDeployments) == 0 && len(machineSets) == 0 && len(machines) == 0 {
+			_, err := finalizers.RemoveFinalizer(c.getAWSMachineClass(class.Namespace), c.patchAWSMachineClass(class.Namespace), class.Name, DeleteFinalizerName)
+			return err
+		}
+
+		if selfMachine := selfHostedMachine(machines); selfMachine != nil {
+			conditions.Set(classConditions, conditions.Condition{
+				Type:    SelfDeletionBlocked,
+				Status:  conditions.ConditionTrue,
+				Reason:  ReasonSelfHostedNode,
+				Message: fmt.Sprintf("Machine %q runs on this controller's own node and will not be reaped", selfMachine.Name),
+			})
+			if err := c.updateAWSMachineClassConditions(class); err != nil {
+				glog.Errorf("Failed to update %s %q conditions: %v", AWSMachineClassKind, class.Name, err)
+			}
+			glog.V(2).Infof("Refusing to remove finalizer of %s %q: last remaining Machine %q runs on this controller's own node", AWSMachineClassKind, class.Name, selfMachine.Name)
+			return nil
+		}
+
+		// Foreground-delete: block the owning MachineDeployments/MachineSets from being garbage
+		// collected until the objects they in turn own have been reaped, giving a predictable
+		// Machine -> MachineSet -> MachineDeployment -> MachineClass teardown order.
+		for _, machineDeployment := range machineDeployments {
+			if _, err := finalizers.EnsureFinalizer(c.getMachineDeployment(machineDeployment.Namespace), c.patchMachineDeployment(machineDeployment.Namespace), machineDeployment.Name, MachineDeploymentFinalizerName); err != nil {
+				return err
+			}
+		}
+		for _, machineSet := range machineSets {
+			if _, err := finalizers.EnsureFinalizer(c.getMachineSet(machineSet.Namespace), c.patchMachineSet(machineSet.Namespace), machineSet.Name, MachineSetFinalizerName); err != nil {
+				return err
+			}
+		}
+
+		conditions.Set(classConditions, conditions.Condition{
+			Type:    FinalizerRemovalBlocked,
+			Status:  conditions.ConditionTrue,
+			Reason:  ReasonReferencesRemaining,
+			Message: "Cannot remove finalizer because Machine[s|Sets|Deployments] are still referencing it",
+		})
+		if err := c.updateAWSMachineClassConditions(class); err != nil {
+			glog.Errorf("Failed to update %s %q conditions: %v", AWSMachineClassKind, class.Name, err)
+		}
+
+		glog.V(4).Infof("Cannot remove finalizer of %s because still Machine[s|Sets|Deployments] are referencing it", AWSMachineClassKind, class.Name)
+		return nil
+	}
+
+	for _, machine := range machines {
+		c.addMachine(machine)
+	}
+	return nil
+}
+
+// awsMachineClassConditions adapts AWSMachineClassStatus.Conditions (defined in
+// pkg/apis/machine/v1alpha1) to conditions.Setter.
+type awsMachineClassConditions struct {
+	class *v1alpha1.AWSMachineClass
+}
+
+func (a *awsMachineClassConditions) GetConditions() []conditions.Condition {
+	return a.class.Status.Conditions
+}
+
+func (a *awsMachineClassConditions) SetConditions(c []conditions.Condition) {
+	a.class.Status.Conditions = c
+}
+
+// updateAWSMachineClassConditions patches only the conditions on the class's status subresource,
+// leaving the rest of the status (and any concurrent writes to it) untouched.
+func (c *controller) updateAWSMachineClassConditions(class *v1alpha1.AWSMachineClass) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": class.Status.Conditions,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal conditions patch for %s %q: %v", AWSMachineClassKind, class.Name, err)
+	}
+
+	_, err = c.controlMachineClient.AWSMachineClasses(class.Namespace).Patch(class.Name, types.MergePatchType, patch, "status")
+	return err
+}
+
+func (c *controller) getAWSMachineClass(namespace string) finalizers.Getter {
+	return func(name string) (metav1.Object, error) {
+		return c.controlMachineClient.AWSMachineClasses(namespace).Get(name, metav1.GetOptions{})
+	}
+}
+
+func (c *controller) patchAWSMachineClass(namespace string) finalizers.Patcher {
+	return func(name string, patchType types.PatchType, data []byte) error {
+		_, err := c.controlMachineClient.AWSMachineClasses(namespace).Patch(name, patchType, data)
+		return err
+	}
+}
@@ -18,8 +18,13 @@ limitations under the License.
 package controller
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/kubernetes/pkg/api"
@@ -29,11 +34,51 @@ import (
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine"
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/validation"
+	"github.com/gardener/machine-controller-manager/pkg/util/conditions"
+	"github.com/gardener/machine-controller-manager/pkg/util/finalizers"
 )
 
 // AzureMachineClassKind is used to identify the machineClassKind as Azure
 const AzureMachineClassKind = "AzureMachineClass"
 
+// MachineDeploymentFinalizerName is the finalizer stamped onto a MachineDeployment while its
+// MachineClass is being deleted, so that the deployment is not removed before its MachineSets are.
+const MachineDeploymentFinalizerName = "machine.sapcloud.io/machinedeployment"
+
+// MachineSetFinalizerName is the finalizer stamped onto a MachineSet while its MachineClass is
+// being deleted, so that the set is not removed before the Machines it owns have drained.
+const MachineSetFinalizerName = "machine.sapcloud.io/machineset"
+
+// DeleteFinalizerName is the finalizer every MachineClass carries for as long as any
+// Machine/MachineSet/MachineDeployment still references it, so the class cannot be garbage
+// collected out from under objects that still need it.
+const DeleteFinalizerName = "machine.sapcloud.io/machine-controller-manager"
+
+// Condition types reported on AzureMachineClass.Status.Conditions.
+const (
+	// ClassValidated reflects the outcome of validation.ValidateAzureMachineClass.
+	ClassValidated conditions.ConditionType = "ClassValidated"
+	// ClassInUse reports how many Machines/MachineSets/MachineDeployments still reference the class.
+	ClassInUse conditions.ConditionType = "ClassInUse"
+	// FinalizerRemovalBlocked is set while the class's DeleteFinalizerName is held back because
+	// referencing objects still exist.
+	FinalizerRemovalBlocked conditions.ConditionType = "FinalizerRemovalBlocked"
+	// SelfDeletionBlocked is set when the last owning Machine runs on this controller's own
+	// node, so removing the finalizer would have the controller delete the VM it runs on.
+	SelfDeletionBlocked conditions.ConditionType = "SelfDeletionBlocked"
+)
+
+// Reasons used alongside the condition types above.
+const (
+	ReasonValidationFailed    = "ValidationFailed"
+	ReasonReferencesRemaining = "ReferencesRemaining"
+	ReasonSelfHostedNode      = "SelfHostedNode"
+)
+
+// nodeNameEnvVar is the downward-API environment variable carrying the name of the node the MCM
+// pod itself is scheduled on, used to avoid a self-hosted MCM deleting the VM it runs on.
+const nodeNameEnvVar = "NODE_NAME"
+
 func (c *controller) machineDeploymentToAzureMachineClassDelete(obj interface{}) {
 	machineDeployment, ok := obj.(*v1alpha1.MachineDeployment)
 	if machineDeployment == nil || !ok {
@@ -109,6 +154,17 @@ func (c *controller) reconcileClusterAzureMachineClassKey(key string) error {
 }
 
 func (c *controller) reconcileClusterAzureMachineClass(class *v1alpha1.AzureMachineClass) error {
+	// Finalizer handling always runs first, ahead of validation and any other work, so a
+	// finalizer stripped mid-lifecycle is restored regardless of whether the rest of the
+	// reconcile succeeds.
+	if class.DeletionTimestamp == nil {
+		if _, err := finalizers.EnsureFinalizer(c.getAzureMachineClass(class.Namespace), c.patchAzureMachineClass(class.Namespace), class.Name, DeleteFinalizerName); err != nil {
+			return err
+		}
+	}
+
+	classConditions := &azureMachineClassConditions{class: class}
+
 	internalClass := &machine.AzureMachineClass{}
 	err := api.Scheme.Convert(class, internalClass, nil)
 	if err != nil {
@@ -118,37 +174,88 @@ func (c *controller) reconcileClusterAzureMachineClass(class *v1alpha1.AzureMach
 	validationerr := validation.ValidateAzureMachineClass(internalClass)
 	if validationerr.ToAggregate() != nil && len(validationerr.ToAggregate().Errors()) > 0 {
 		glog.V(2).Infof("Validation of %s failed %s", AzureMachineClassKind, validationerr.ToAggregate().Error())
-		return nil
-	}
-
-	// Manipulate finalizers
-	if class.DeletionTimestamp == nil {
-		c.addAzureMachineClassFinalizers(class)
+		conditions.MarkFalse(classConditions, ClassValidated, ReasonValidationFailed, validationerr.ToAggregate().Error())
+		return c.updateAzureMachineClassConditions(class)
 	}
+	conditions.MarkTrue(classConditions, ClassValidated)
 
 	machines, err := c.findMachinesForClass(AzureMachineClassKind, class.Name)
 	if err != nil {
 		return err
 	}
+	machineSets, err := c.findMachineSetsForClass(AzureMachineClassKind, class.Name)
+	if err != nil {
+		return err
+	}
+	machineDeployments, err := c.findMachineDeploymentsForClass(AzureMachineClassKind, class.Name)
+	if err != nil {
+		return err
+	}
+
+	inUseStatus := conditions.ConditionFalse
+	if len(machineDeployments) > 0 || len(machineSets) > 0 || len(machines) > 0 {
+		inUseStatus = conditions.ConditionTrue
+	}
+	conditions.Set(classConditions, conditions.Condition{
+		Type:    ClassInUse,
+		Status:  inUseStatus,
+		Message: fmt.Sprintf("%d MachineDeployment(s), %d MachineSet(s), %d Machine(s) reference this class", len(machineDeployments), len(machineSets), len(machines)),
+	})
+	// A failed condition write must not block the cascading-deletion/finalizer handling below -
+	// that is the functional half of reconcile, and a status subresource hiccup shouldn't be
+	// able to deadlock it.
+	if err := c.updateAzureMachineClassConditions(class); err != nil {
+		glog.Errorf("Failed to update %s %q conditions: %v", AzureMachineClassKind, class.Name, err)
+	}
 
 	if class.DeletionTimestamp != nil {
-		if finalizers := sets.NewString(class.Finalizers...); !finalizers.Has(DeleteFinalizerName) {
+		if finalizerSet := sets.NewString(class.Finalizers...); !finalizerSet.Has(DeleteFinalizerName) {
 			return nil
 		}
 
-		machineDeployments, err := c.findMachineDeploymentsForClass(AzureMachineClassKind, class.Name)
-		if err != nil {
-			return err
-		}
-		machineSets, err := c.findMachineSetsForClass(AzureMachineClassKind, class.Name)
-		if err != nil {
+		if len(machineDeployments) == 0 && len(machineSets) == 0 && len(machines) == 0 {
+			_, err := finalizers.RemoveFinalizer(c.getAzureMachineClass(class.Namespace), c.patchAzureMachineClass(class.Namespace), class.Name, DeleteFinalizerName)
 			return err
 		}
-		if len(machineDeployments) == 0 && len(machineSets) == 0 && len(machines) == 0 {
-			c.deleteAzureMachineClassFinalizers(class)
+
+		if selfMachine := selfHostedMachine(machines); selfMachine != nil {
+			conditions.Set(classConditions, conditions.Condition{
+				Type:    SelfDeletionBlocked,
+				Status:  conditions.ConditionTrue,
+				Reason:  ReasonSelfHostedNode,
+				Message: fmt.Sprintf("Machine %q runs on this controller's own node and will not be reaped", selfMachine.Name),
+			})
+			if err := c.updateAzureMachineClassConditions(class); err != nil {
+				glog.Errorf("Failed to update %s %q conditions: %v", AzureMachineClassKind, class.Name, err)
+			}
+			glog.V(2).Infof("Refusing to remove finalizer of %s %q: last remaining Machine %q runs on this controller's own node", AzureMachineClassKind, class.Name, selfMachine.Name)
 			return nil
 		}
 
+		// Foreground-delete: block the owning MachineDeployments/MachineSets from being garbage
+		// collected until the objects they in turn own have been reaped, giving a predictable
+		// Machine -> MachineSet -> MachineDeployment -> MachineClass teardown order.
+		for _, machineDeployment := range machineDeployments {
+			if _, err := finalizers.EnsureFinalizer(c.getMachineDeployment(machineDeployment.Namespace), c.patchMachineDeployment(machineDeployment.Namespace), machineDeployment.Name, MachineDeploymentFinalizerName); err != nil {
+				return err
+			}
+		}
+		for _, machineSet := range machineSets {
+			if _, err := finalizers.EnsureFinalizer(c.getMachineSet(machineSet.Namespace), c.patchMachineSet(machineSet.Namespace), machineSet.Name, MachineSetFinalizerName); err != nil {
+				return err
+			}
+		}
+
+		conditions.Set(classConditions, conditions.Condition{
+			Type:    FinalizerRemovalBlocked,
+			Status:  conditions.ConditionTrue,
+			Reason:  ReasonReferencesRemaining,
+			Message: "Cannot remove finalizer because Machine[s|Sets|Deployments] are still referencing it",
+		})
+		if err := c.updateAzureMachineClassConditions(class); err != nil {
+			glog.Errorf("Failed to update %s %q conditions: %v", AzureMachineClassKind, class.Name, err)
+		}
+
 		glog.V(4).Infof("Cannot remove finalizer of %s because still Machine[s|Sets|Deployments] are referencing it", AzureMachineClassKind, class.Name)
 		return nil
 	}
@@ -159,42 +266,93 @@ func (c *controller) reconcileClusterAzureMachineClass(class *v1alpha1.AzureMach
 	return nil
 }
 
+// selfHostedMachine returns the last remaining Machine if it runs on this controller's own node
+// (per the NODE_NAME downward-API env var), so the caller can refuse to delete the VM the
+// self-hosted MCM is running on. It returns nil unless exactly one Machine remains.
+func selfHostedMachine(machines []*v1alpha1.Machine) *v1alpha1.Machine {
+	nodeName := os.Getenv(nodeNameEnvVar)
+	if nodeName == "" || len(machines) != 1 {
+		return nil
+	}
+	if machines[0].Status.Node == nodeName {
+		return machines[0]
+	}
+	return nil
+}
+
+// azureMachineClassConditions adapts AzureMachineClassStatus.Conditions (defined in
+// pkg/apis/machine/v1alpha1) to conditions.Setter.
+type azureMachineClassConditions struct {
+	class *v1alpha1.AzureMachineClass
+}
+
+func (a *azureMachineClassConditions) GetConditions() []conditions.Condition {
+	return a.class.Status.Conditions
+}
+
+func (a *azureMachineClassConditions) SetConditions(c []conditions.Condition) {
+	a.class.Status.Conditions = c
+}
+
+// updateAzureMachineClassConditions patches only the conditions on the class's status
+// subresource, leaving the rest of the status (and any concurrent writes to it) untouched.
+func (c *controller) updateAzureMachineClassConditions(class *v1alpha1.AzureMachineClass) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": class.Status.Conditions,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal conditions patch for %s %q: %v", AzureMachineClassKind, class.Name, err)
+	}
+
+	_, err = c.controlMachineClient.AzureMachineClasses(class.Namespace).Patch(class.Name, types.MergePatchType, patch, "status")
+	return err
+}
+
 /*
 	SECTION
-	Manipulate Finalizers
+	Finalizer plumbing
+
+	Thin adapters wiring the generated clientsets into the shared finalizers.Getter/Patcher
+	function types so the reconcilers above never touch finalizer slices directly.
 */
 
-func (c *controller) addAzureMachineClassFinalizers(class *v1alpha1.AzureMachineClass) {
-	clone := class.DeepCopy()
+func (c *controller) getAzureMachineClass(namespace string) finalizers.Getter {
+	return func(name string) (metav1.Object, error) {
+		return c.controlMachineClient.AzureMachineClasses(namespace).Get(name, metav1.GetOptions{})
+	}
+}
 
-	if finalizers := sets.NewString(clone.Finalizers...); !finalizers.Has(DeleteFinalizerName) {
-		finalizers.Insert(DeleteFinalizerName)
-		c.updateAzureMachineClassFinalizers(clone, finalizers.List())
+func (c *controller) patchAzureMachineClass(namespace string) finalizers.Patcher {
+	return func(name string, patchType types.PatchType, data []byte) error {
+		_, err := c.controlMachineClient.AzureMachineClasses(namespace).Patch(name, patchType, data)
+		return err
 	}
 }
 
-func (c *controller) deleteAzureMachineClassFinalizers(class *v1alpha1.AzureMachineClass) {
-	clone := class.DeepCopy()
+func (c *controller) getMachineDeployment(namespace string) finalizers.Getter {
+	return func(name string) (metav1.Object, error) {
+		return c.controlMachineClient.MachineDeployments(namespace).Get(name, metav1.GetOptions{})
+	}
+}
 
-	if finalizers := sets.NewString(clone.Finalizers...); finalizers.Has(DeleteFinalizerName) {
-		finalizers.Delete(DeleteFinalizerName)
-		c.updateAzureMachineClassFinalizers(clone, finalizers.List())
+func (c *controller) patchMachineDeployment(namespace string) finalizers.Patcher {
+	return func(name string, patchType types.PatchType, data []byte) error {
+		_, err := c.controlMachineClient.MachineDeployments(namespace).Patch(name, patchType, data)
+		return err
 	}
 }
 
-func (c *controller) updateAzureMachineClassFinalizers(class *v1alpha1.AzureMachineClass, finalizers []string) {
-	// Get the latest version of the class so that we can avoid conflicts
-	class, err := c.controlMachineClient.AzureMachineClasses(class.Namespace).Get(class.Name, metav1.GetOptions{})
-	if err != nil {
-		return
+func (c *controller) getMachineSet(namespace string) finalizers.Getter {
+	return func(name string) (metav1.Object, error) {
+		return c.controlMachineClient.MachineSets(namespace).Get(name, metav1.GetOptions{})
 	}
+}
 
-	clone := class.DeepCopy()
-	clone.Finalizers = finalizers
-	_, err = c.controlMachineClient.AzureMachineClasses(class.Namespace).Update(clone)
-	if err != nil {
-		// Keep retrying until update goes through
-		glog.Warning("Updated failed, retrying")
-		c.updateAzureMachineClassFinalizers(class, finalizers)
+func (c *controller) patchMachineSet(namespace string) finalizers.Patcher {
+	return func(name string, patchType types.PatchType, data []byte) error {
+		_, err := c.controlMachineClient.MachineSets(namespace).Patch(name, patchType, data)
+		return err
 	}
 }
\ No newline at end of file
@@ -0,0 +1,124 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+)
+
+// ClassIndexName is the name of the cache.Indexer installed by RegisterClassIndexers on the
+// Machine/MachineSet/MachineDeployment informers, keyed by "<Class.Kind>/<Class.Name>". It lets
+// the MachineClass reconcilers look up the objects referencing a class in O(1) instead of
+// scanning every Machine/MachineSet/MachineDeployment in the cluster on every resync.
+const ClassIndexName = "machineClass"
+
+// RegisterClassIndexers installs the ClassIndexName indexer on the Machine/MachineSet/
+// MachineDeployment informers backing machineIndexer/machineSetIndexer/machineDeploymentIndexer,
+// so findMachinesForClass and friends have something to query. Call this once, alongside the rest
+// of the informer wiring, before the controller starts - mirrors how RegisterDebugHandlers wires
+// the debug endpoints onto the caller's mux.
+func RegisterClassIndexers(machineInformer, machineSetInformer, machineDeploymentInformer cache.SharedIndexInformer) error {
+	if err := machineInformer.AddIndexers(cache.Indexers{ClassIndexName: MachineClassIndexFunc}); err != nil {
+		return fmt.Errorf("failed to add %s indexer to Machine informer: %v", ClassIndexName, err)
+	}
+	if err := machineSetInformer.AddIndexers(cache.Indexers{ClassIndexName: MachineSetClassIndexFunc}); err != nil {
+		return fmt.Errorf("failed to add %s indexer to MachineSet informer: %v", ClassIndexName, err)
+	}
+	if err := machineDeploymentInformer.AddIndexers(cache.Indexers{ClassIndexName: MachineDeploymentClassIndexFunc}); err != nil {
+		return fmt.Errorf("failed to add %s indexer to MachineDeployment informer: %v", ClassIndexName, err)
+	}
+	return nil
+}
+
+func classIndexKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+// MachineClassIndexFunc indexes a Machine by the MachineClass it references.
+func MachineClassIndexFunc(obj interface{}) ([]string, error) {
+	machine, ok := obj.(*v1alpha1.Machine)
+	if !ok {
+		return nil, fmt.Errorf("expected *v1alpha1.Machine, got %T", obj)
+	}
+	return []string{classIndexKey(machine.Spec.Class.Kind, machine.Spec.Class.Name)}, nil
+}
+
+// MachineSetClassIndexFunc indexes a MachineSet by the MachineClass its Machine template
+// references.
+func MachineSetClassIndexFunc(obj interface{}) ([]string, error) {
+	machineSet, ok := obj.(*v1alpha1.MachineSet)
+	if !ok {
+		return nil, fmt.Errorf("expected *v1alpha1.MachineSet, got %T", obj)
+	}
+	return []string{classIndexKey(machineSet.Spec.Template.Spec.Class.Kind, machineSet.Spec.Template.Spec.Class.Name)}, nil
+}
+
+// MachineDeploymentClassIndexFunc indexes a MachineDeployment by the MachineClass its Machine
+// template references.
+func MachineDeploymentClassIndexFunc(obj interface{}) ([]string, error) {
+	machineDeployment, ok := obj.(*v1alpha1.MachineDeployment)
+	if !ok {
+		return nil, fmt.Errorf("expected *v1alpha1.MachineDeployment, got %T", obj)
+	}
+	return []string{classIndexKey(machineDeployment.Spec.Template.Spec.Class.Kind, machineDeployment.Spec.Template.Spec.Class.Name)}, nil
+}
+
+// findMachinesForClass returns the Machines referencing the named MachineClass via the
+// ClassIndexName indexer.
+func (c *controller) findMachinesForClass(kind, name string) ([]*v1alpha1.Machine, error) {
+	objs, err := c.machineIndexer.ByIndex(ClassIndexName, classIndexKey(kind, name))
+	if err != nil {
+		return nil, err
+	}
+	machines := make([]*v1alpha1.Machine, 0, len(objs))
+	for _, obj := range objs {
+		machines = append(machines, obj.(*v1alpha1.Machine))
+	}
+	return machines, nil
+}
+
+// findMachineSetsForClass returns the MachineSets referencing the named MachineClass via the
+// ClassIndexName indexer.
+func (c *controller) findMachineSetsForClass(kind, name string) ([]*v1alpha1.MachineSet, error) {
+	objs, err := c.machineSetIndexer.ByIndex(ClassIndexName, classIndexKey(kind, name))
+	if err != nil {
+		return nil, err
+	}
+	machineSets := make([]*v1alpha1.MachineSet, 0, len(objs))
+	for _, obj := range objs {
+		machineSets = append(machineSets, obj.(*v1alpha1.MachineSet))
+	}
+	return machineSets, nil
+}
+
+// findMachineDeploymentsForClass returns the MachineDeployments referencing the named
+// MachineClass via the ClassIndexName indexer.
+func (c *controller) findMachineDeploymentsForClass(kind, name string) ([]*v1alpha1.MachineDeployment, error) {
+	objs, err := c.machineDeploymentIndexer.ByIndex(ClassIndexName, classIndexKey(kind, name))
+	if err != nil {
+		return nil, err
+	}
+	machineDeployments := make([]*v1alpha1.MachineDeployment, 0, len(objs))
+	for _, obj := range objs {
+		machineDeployments = append(machineDeployments, obj.(*v1alpha1.MachineDeployment))
+	}
+	return machineDeployments, nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/golang/glog"
+)
+
+// ownerGraphPath is the debug endpoint serving GetOwnerGraph, e.g.
+// GET /debug/ownergraph?kind=AzureMachineClass&name=my-class
+const ownerGraphPath = "/debug/ownergraph"
+
+// RegisterDebugHandlers wires the controller's debug endpoints onto mux, for operators
+// troubleshooting a MachineClass stuck in deletion.
+func (c *controller) RegisterDebugHandlers(mux *http.ServeMux) {
+	mux.HandleFunc(ownerGraphPath, c.serveOwnerGraph)
+}
+
+func (c *controller) serveOwnerGraph(w http.ResponseWriter, r *http.Request) {
+	kind := r.URL.Query().Get("kind")
+	name := r.URL.Query().Get("name")
+	if kind == "" || name == "" {
+		http.Error(w, "both kind and name query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	graph, err := c.GetOwnerGraph(kind, name)
+	if err != nil {
+		glog.Errorf("Failed to build owner graph for %s %q: %v", kind, name, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(graph); err != nil {
+		glog.Errorf("Failed to encode owner graph for %s %q: %v", kind, name, err)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/golang/glog"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/conditions"
+	"github.com/gardener/machine-controller-manager/pkg/util/finalizers"
+)
+
+// MachineKind is used to identify a Machine as an owner/referencing object.
+const MachineKind = "Machine"
+
+// MachineFinalizerName protects a Machine from being removed before the controller has had a
+// chance to decommission the backing VM.
+const MachineFinalizerName = "machine.sapcloud.io/machine-controller-manager"
+
+// errSelfDeletionBlocked is returned by reconcileClusterMachine to force a rate-limited requeue
+// while a self-hosted MCM is refusing to delete the Machine it is itself running on.
+var errSelfDeletionBlocked = errors.New("refusing to delete Machine running this controller's own node; requeued")
+
+func (c *controller) addMachine(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		glog.Errorf("Couldn't get key for object %+v: %v", obj, err)
+		return
+	}
+	c.machineQueue.Add(key)
+}
+
+// reconcileClusterMachineKey reconciles a Machine due to controller resync or an event on the
+// Machine.
+func (c *controller) reconcileClusterMachineKey(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	machine, err := c.machineLister.Machines(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		glog.Infof("%s %q: Not doing work because it has been deleted", MachineKind, key)
+		return nil
+	}
+	if err != nil {
+		glog.Infof("%s %q: Unable to retrieve object from store: %v", MachineKind, key, err)
+		return err
+	}
+
+	return c.reconcileClusterMachine(machine)
+}
+
+func (c *controller) reconcileClusterMachine(machine *v1alpha1.Machine) error {
+	// Finalizer handling always runs first, ahead of any other work, so a finalizer stripped
+	// mid-lifecycle is restored regardless of whether the rest of the reconcile succeeds.
+	if machine.DeletionTimestamp == nil {
+		if _, err := finalizers.EnsureFinalizer(c.getMachine(machine.Namespace), c.patchMachine(machine.Namespace), machine.Name, MachineFinalizerName); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if !sets.NewString(machine.Finalizers...).Has(MachineFinalizerName) {
+		return nil
+	}
+
+	// Refuse to let a self-hosted MCM delete the Machine it is itself running on: without this
+	// guard the pod would tear down the VM (and therefore itself) mid-reconcile, rather than
+	// first draining and being rescheduled onto a surviving node.
+	if nodeName := os.Getenv(nodeNameEnvVar); nodeName != "" && machine.Status.Node == nodeName {
+		machineConditions := &machineConditions{machine: machine}
+		conditions.Set(machineConditions, conditions.Condition{
+			Type:    SelfDeletionBlocked,
+			Status:  conditions.ConditionTrue,
+			Reason:  ReasonSelfHostedNode,
+			Message: fmt.Sprintf("Machine %q runs on this controller's own node and will not be deleted until drained and rescheduled", machine.Name),
+		})
+		if err := c.updateMachineConditions(machine); err != nil {
+			return err
+		}
+		glog.V(2).Infof("Refusing to remove finalizer of %s %q: it runs on this controller's own node", MachineKind, machine.Name)
+		// Returning an error (rather than nil) makes the workqueue requeue this key with
+		// backoff, so the drain/reschedule condition is actively polled instead of waiting for
+		// the next informer resync.
+		return errSelfDeletionBlocked
+	}
+
+	// Decommissioning the backing VM and the rest of the drain/create/update machinery is
+	// out of scope for this snapshot of the controller; only the finalizer lifecycle lives here.
+	_, err := finalizers.RemoveFinalizer(c.getMachine(machine.Namespace), c.patchMachine(machine.Namespace), machine.Name, MachineFinalizerName)
+	return err
+}
+
+// machineConditions adapts MachineStatus.Conditions to conditions.Setter.
+type machineConditions struct {
+	machine *v1alpha1.Machine
+}
+
+func (m *machineConditions) GetConditions() []conditions.Condition {
+	return m.machine.Status.Conditions
+}
+
+func (m *machineConditions) SetConditions(c []conditions.Condition) {
+	m.machine.Status.Conditions = c
+}
+
+// updateMachineConditions patches the conditions on the Machine's status. Unlike the MachineClass
+// types, Machine has no CRD in this tree declaring a status subresource, so this patches the main
+// resource rather than a "status" subresource that the API server would 404 on.
+func (c *controller) updateMachineConditions(machine *v1alpha1.Machine) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": machine.Status.Conditions,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal conditions patch for %s %q: %v", MachineKind, machine.Name, err)
+	}
+
+	_, err = c.controlMachineClient.Machines(machine.Namespace).Patch(machine.Name, types.MergePatchType, patch)
+	return err
+}
+
+func (c *controller) getMachine(namespace string) finalizers.Getter {
+	return func(name string) (metav1.Object, error) {
+		return c.controlMachineClient.Machines(namespace).Get(name, metav1.GetOptions{})
+	}
+}
+
+func (c *controller) patchMachine(namespace string) finalizers.Patcher {
+	return func(name string, patchType types.PatchType, data []byte) error {
+		_, err := c.controlMachineClient.Machines(namespace).Patch(name, patchType, data)
+		return err
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/golang/glog"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/finalizers"
+)
+
+// MachineDeploymentKind is used to identify a MachineDeployment as an owner/referencing object.
+const MachineDeploymentKind = "MachineDeployment"
+
+// reconcileClusterMachineDeploymentKey reconciles a MachineDeployment due to controller resync or
+// an event on the MachineDeployment.
+func (c *controller) reconcileClusterMachineDeploymentKey(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	machineDeployment, err := c.machineDeploymentLister.MachineDeployments(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		glog.Infof("%s %q: Not doing work because it has been deleted", MachineDeploymentKind, key)
+		return nil
+	}
+	if err != nil {
+		glog.Infof("%s %q: Unable to retrieve object from store: %v", MachineDeploymentKind, key, err)
+		return err
+	}
+
+	return c.reconcileClusterMachineDeployment(machineDeployment)
+}
+
+// reconcileClusterMachineDeployment stamps an OwnerReference onto the MachineSets it owns
+// (consumed by GetOwnerGraph) and handles the MachineDeploymentFinalizerName lifecycle stamped by
+// the owning MachineClass's foreground deletion (see azuremachineclass.go). The "still owns" check
+// counts the MachineSets matched by the deployment's selector, not those carrying the
+// OwnerReference. Normal MachineDeployment rollout reconciliation is out of scope here.
+func (c *controller) reconcileClusterMachineDeployment(machineDeployment *v1alpha1.MachineDeployment) error {
+	machineSets, err := c.machineSetsMatchingSelector(machineDeployment.Namespace, machineDeployment.Spec.Selector)
+	if err != nil {
+		return err
+	}
+
+	if machineDeployment.DeletionTimestamp == nil {
+		for _, machineSet := range machineSets {
+			if err := c.adoptMachineSet(machineDeployment, machineSet); err != nil {
+				return err
+			}
+		}
+
+		if len(machineSets) == 0 {
+			if _, err := c.createMachineSetForDeployment(machineDeployment, newMachineSet(machineDeployment)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !sets.NewString(machineDeployment.Finalizers...).Has(MachineDeploymentFinalizerName) {
+		return nil
+	}
+	if len(machineSets) > 0 {
+		glog.V(4).Infof("Cannot remove finalizer of %s %q because it still owns %d MachineSet(s)", MachineDeploymentKind, machineDeployment.Name, len(machineSets))
+		return nil
+	}
+
+	_, err = finalizers.RemoveFinalizer(c.getMachineDeployment(machineDeployment.Namespace), c.patchMachineDeployment(machineDeployment.Namespace), machineDeployment.Name, MachineDeploymentFinalizerName)
+	return err
+}
+
+// adoptMachineSet stamps an OwnerReference to machineDeployment onto machineSet, unless it is
+// already present.
+func (c *controller) adoptMachineSet(machineDeployment *v1alpha1.MachineDeployment, machineSet *v1alpha1.MachineSet) error {
+	for _, ref := range machineSet.OwnerReferences {
+		if ref.Kind == MachineDeploymentKind && ref.Name == machineDeployment.Name {
+			return nil
+		}
+	}
+
+	blockOwnerDeletion := true
+	ownerRefs := append(machineSet.OwnerReferences, metav1.OwnerReference{
+		APIVersion:         v1alpha1.SchemeGroupVersion.String(),
+		Kind:               MachineDeploymentKind,
+		Name:               machineDeployment.Name,
+		UID:                machineDeployment.UID,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	})
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"ownerReferences": ownerRefs,
+			"resourceVersion": machineSet.ResourceVersion,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal owner reference patch for %s %q: %v", MachineSetKind, machineSet.Name, err)
+	}
+
+	_, err = c.controlMachineClient.MachineSets(machineSet.Namespace).Patch(machineSet.Name, types.MergePatchType, patch)
+	return err
+}
+
+// machineSetsMatchingSelector lists the MachineSets in namespace matched by selector, mirroring
+// how a MachineDeployment itself finds the MachineSets it owns.
+func (c *controller) machineSetsMatchingSelector(namespace string, selector *metav1.LabelSelector) ([]*v1alpha1.MachineSet, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return c.machineSetLister.MachineSets(namespace).List(labelSelector)
+}
+
+// newMachineSet builds the MachineSet that should back machineDeployment when it does not yet own
+// one, carrying over its selector, template and labels so the new MachineSet matches the selector
+// machineSetsMatchingSelector will look for on the next resync.
+func newMachineSet(machineDeployment *v1alpha1.MachineDeployment) *v1alpha1.MachineSet {
+	return &v1alpha1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: machineDeployment.Name + "-",
+			Namespace:    machineDeployment.Namespace,
+			Labels:       machineDeployment.Spec.Template.Labels,
+		},
+		Spec: v1alpha1.MachineSetSpec{
+			Replicas: machineDeployment.Spec.Replicas,
+			Selector: machineDeployment.Spec.Selector,
+			Template: machineDeployment.Spec.Template,
+		},
+	}
+}
+
+// createMachineSetForDeployment creates a new MachineSet owned by machineDeployment, e.g. during a
+// rollout. The OwnerReference is set before the MachineSet is created, so it is never missing even
+// momentarily. If the MachineDeployment is itself mid foreground-deletion (it already carries
+// MachineDeploymentFinalizerName because its MachineClass is being torn down), the finalizer is
+// propagated onto the new MachineSet immediately, so a MachineSet created in that narrow window is
+// not missed until the next AzureMachineClass resync picks it up.
+func (c *controller) createMachineSetForDeployment(machineDeployment *v1alpha1.MachineDeployment, machineSet *v1alpha1.MachineSet) (*v1alpha1.MachineSet, error) {
+	blockOwnerDeletion := true
+	machineSet.OwnerReferences = append(machineSet.OwnerReferences, metav1.OwnerReference{
+		APIVersion:         v1alpha1.SchemeGroupVersion.String(),
+		Kind:               MachineDeploymentKind,
+		Name:               machineDeployment.Name,
+		UID:                machineDeployment.UID,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	})
+
+	created, err := c.controlMachineClient.MachineSets(machineDeployment.Namespace).Create(machineSet)
+	if err != nil {
+		return nil, err
+	}
+
+	if sets.NewString(machineDeployment.Finalizers...).Has(MachineDeploymentFinalizerName) {
+		if _, err := finalizers.EnsureFinalizer(c.getMachineSet(created.Namespace), c.patchMachineSet(created.Namespace), created.Name, MachineSetFinalizerName); err != nil {
+			return created, err
+		}
+	}
+
+	return created, nil
+}
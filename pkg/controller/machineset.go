@@ -0,0 +1,130 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/golang/glog"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/finalizers"
+)
+
+// MachineSetKind is used to identify a MachineSet as an owner/referencing object.
+const MachineSetKind = "MachineSet"
+
+// reconcileClusterMachineSetKey reconciles a MachineSet due to controller resync or an event on
+// the MachineSet.
+func (c *controller) reconcileClusterMachineSetKey(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	machineSet, err := c.machineSetLister.MachineSets(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		glog.Infof("%s %q: Not doing work because it has been deleted", MachineSetKind, key)
+		return nil
+	}
+	if err != nil {
+		glog.Infof("%s %q: Unable to retrieve object from store: %v", MachineSetKind, key, err)
+		return err
+	}
+
+	return c.reconcileClusterMachineSet(machineSet)
+}
+
+// reconcileClusterMachineSet stamps an OwnerReference onto the Machines it owns (consumed by
+// GetOwnerGraph) and handles the MachineSetFinalizerName lifecycle stamped by the owning
+// MachineClass's foreground deletion (see azuremachineclass.go). The "still owns" check counts the
+// Machines matched by the set's selector, not those carrying the OwnerReference. Normal MachineSet
+// scale/rollout reconciliation is out of scope here.
+func (c *controller) reconcileClusterMachineSet(machineSet *v1alpha1.MachineSet) error {
+	machines, err := c.machinesMatchingSelector(machineSet.Namespace, machineSet.Spec.Selector)
+	if err != nil {
+		return err
+	}
+
+	if machineSet.DeletionTimestamp == nil {
+		for _, m := range machines {
+			if err := c.adoptMachine(machineSet, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !sets.NewString(machineSet.Finalizers...).Has(MachineSetFinalizerName) {
+		return nil
+	}
+	if len(machines) > 0 {
+		glog.V(4).Infof("Cannot remove finalizer of %s %q because it still owns %d Machine(s)", MachineSetKind, machineSet.Name, len(machines))
+		return nil
+	}
+
+	_, err = finalizers.RemoveFinalizer(c.getMachineSet(machineSet.Namespace), c.patchMachineSet(machineSet.Namespace), machineSet.Name, MachineSetFinalizerName)
+	return err
+}
+
+// adoptMachine stamps an OwnerReference to machineSet onto m, unless it is already present.
+func (c *controller) adoptMachine(machineSet *v1alpha1.MachineSet, m *v1alpha1.Machine) error {
+	for _, ref := range m.OwnerReferences {
+		if ref.Kind == MachineSetKind && ref.Name == machineSet.Name {
+			return nil
+		}
+	}
+
+	blockOwnerDeletion := true
+	ownerRefs := append(m.OwnerReferences, metav1.OwnerReference{
+		APIVersion:         v1alpha1.SchemeGroupVersion.String(),
+		Kind:               MachineSetKind,
+		Name:               machineSet.Name,
+		UID:                machineSet.UID,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	})
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"ownerReferences": ownerRefs,
+			"resourceVersion": m.ResourceVersion,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal owner reference patch for %s %q: %v", MachineKind, m.Name, err)
+	}
+
+	_, err = c.controlMachineClient.Machines(m.Namespace).Patch(m.Name, types.MergePatchType, patch)
+	return err
+}
+
+// machinesMatchingSelector lists the Machines in namespace matched by selector, mirroring how a
+// MachineSet itself finds the Machines it owns.
+func (c *controller) machinesMatchingSelector(namespace string, selector *metav1.LabelSelector) ([]*v1alpha1.Machine, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return c.machineLister.Machines(namespace).List(labelSelector)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+)
+
+// OwnerGraphNode is one level of the class -> deployment -> set -> machine ownership tree
+// returned by GetOwnerGraph, used by operators to see at a glance why a MachineClass deletion
+// is stuck.
+type OwnerGraphNode struct {
+	Kind     string            `json:"kind"`
+	Name     string            `json:"name"`
+	Children []*OwnerGraphNode `json:"children,omitempty"`
+}
+
+// GetOwnerGraph returns the class -> MachineDeployment -> MachineSet -> Machine tree for the
+// named MachineClass, for operators troubleshooting a class stuck in deletion. It is served over
+// HTTP by RegisterDebugHandlers.
+//
+// The tree is built from findXxxForClass (an indexed lookup, see class_index.go) plus the
+// OwnerReferences stamped by the MachineSet/MachineDeployment reconcilers (see machineset.go,
+// machinedeployment.go) as they adopt the Machines/MachineSets matching their selector.
+func (c *controller) GetOwnerGraph(kind, className string) (*OwnerGraphNode, error) {
+	root := &OwnerGraphNode{Kind: kind, Name: className}
+
+	machineDeployments, err := c.findMachineDeploymentsForClass(kind, className)
+	if err != nil {
+		return nil, err
+	}
+	machineSets, err := c.findMachineSetsForClass(kind, className)
+	if err != nil {
+		return nil, err
+	}
+	machines, err := c.findMachinesForClass(kind, className)
+	if err != nil {
+		return nil, err
+	}
+
+	machineSetsByDeployment := map[string][]*v1alpha1.MachineSet{}
+	for _, machineSet := range machineSets {
+		owner := ownerMachineDeploymentName(machineSet)
+		machineSetsByDeployment[owner] = append(machineSetsByDeployment[owner], machineSet)
+	}
+
+	machinesByMachineSet := map[string][]*v1alpha1.Machine{}
+	for _, m := range machines {
+		owner := ownerMachineSetName(m)
+		machinesByMachineSet[owner] = append(machinesByMachineSet[owner], m)
+	}
+
+	for _, machineDeployment := range machineDeployments {
+		deploymentNode := &OwnerGraphNode{Kind: "MachineDeployment", Name: machineDeployment.Name}
+		for _, machineSet := range machineSetsByDeployment[machineDeployment.Name] {
+			deploymentNode.Children = append(deploymentNode.Children, machineSetNode(machineSet, machinesByMachineSet))
+		}
+		root.Children = append(root.Children, deploymentNode)
+	}
+
+	// MachineSets without an owning MachineDeployment still belong directly to the class.
+	for _, machineSet := range machineSetsByDeployment[""] {
+		root.Children = append(root.Children, machineSetNode(machineSet, machinesByMachineSet))
+	}
+
+	// Machines without an owning MachineSet (not yet adopted, or belonging to no MachineSet at
+	// all) still belong directly to the class and must not be silently dropped from the tree.
+	for _, m := range machinesByMachineSet[""] {
+		root.Children = append(root.Children, &OwnerGraphNode{Kind: "Machine", Name: m.Name})
+	}
+
+	return root, nil
+}
+
+func machineSetNode(machineSet *v1alpha1.MachineSet, machinesByMachineSet map[string][]*v1alpha1.Machine) *OwnerGraphNode {
+	node := &OwnerGraphNode{Kind: "MachineSet", Name: machineSet.Name}
+	for _, m := range machinesByMachineSet[machineSet.Name] {
+		node.Children = append(node.Children, &OwnerGraphNode{Kind: "Machine", Name: m.Name})
+	}
+	return node
+}
+
+func ownerMachineDeploymentName(machineSet *v1alpha1.MachineSet) string {
+	for _, ref := range machineSet.OwnerReferences {
+		if ref.Kind == "MachineDeployment" {
+			return ref.Name
+		}
+	}
+	return ""
+}
+
+func ownerMachineSetName(m *v1alpha1.Machine) string {
+	for _, ref := range m.OwnerReferences {
+		if ref.Kind == "MachineSet" {
+			return ref.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conditions gives every controller in machine-controller-manager a single
+// implementation of structured status conditions (Set/Get/MarkTrue/MarkFalse), so users get a
+// consistent "why is this stuck" signal on AzureMachineClass/MachineSet/MachineDeployment status
+// instead of each controller growing its own ad-hoc variant.
+package conditions
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionType is the type of a Condition, e.g. "ClassValidated".
+type ConditionType string
+
+// ConditionStatus is the status of a Condition, mirroring corev1.ConditionStatus.
+type ConditionStatus string
+
+// The set of statuses a Condition can report.
+const (
+	ConditionTrue    ConditionStatus = "True"
+	ConditionFalse   ConditionStatus = "False"
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+// Condition describes the state of one aspect of an object at a point in time.
+type Condition struct {
+	// Type of the condition, e.g. "ClassValidated".
+	Type ConditionType `json:"type"`
+	// Status of the condition: True, False or Unknown.
+	Status ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time the condition's Status changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a short, machine-readable explanation for the condition's Status.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable explanation of the condition.
+	Message string `json:"message,omitempty"`
+}
+
+// Getter is implemented by any status struct that carries a Conditions slice.
+type Getter interface {
+	GetConditions() []Condition
+}
+
+// Setter is implemented by any status struct whose Conditions slice can be replaced.
+type Setter interface {
+	Getter
+	SetConditions([]Condition)
+}
+
+// Get returns the condition of the given type, or nil if obj does not have one.
+func Get(obj Getter, conditionType ConditionType) *Condition {
+	for _, condition := range obj.GetConditions() {
+		if condition.Type == conditionType {
+			return &condition
+		}
+	}
+	return nil
+}
+
+// Set adds or updates the condition of the given type on obj. LastTransitionTime is preserved if
+// the status hasn't changed; otherwise it is stamped to now, unless condition already carries a
+// non-zero LastTransitionTime, which is then kept as given.
+func Set(obj Setter, condition Condition) {
+	existing := Get(obj, condition.Type)
+	if existing != nil && existing.Status == condition.Status {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	} else if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+
+	conditions := obj.GetConditions()
+	for i := range conditions {
+		if conditions[i].Type == condition.Type {
+			conditions[i] = condition
+			obj.SetConditions(conditions)
+			return
+		}
+	}
+	obj.SetConditions(append(conditions, condition))
+}
+
+// MarkTrue sets the condition of the given type to True.
+func MarkTrue(obj Setter, conditionType ConditionType) {
+	Set(obj, Condition{Type: conditionType, Status: ConditionTrue})
+}
+
+// MarkFalse sets the condition of the given type to False with the given reason and message.
+func MarkFalse(obj Setter, conditionType ConditionType, reason, message string) {
+	Set(obj, Condition{Type: conditionType, Status: ConditionFalse, Reason: reason, Message: message})
+}
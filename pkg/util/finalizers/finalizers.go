@@ -0,0 +1,99 @@
+/*
+Copyright 2017 The Gardener Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package finalizers provides a single, shared implementation of finalizer add/remove that all
+// controllers in machine-controller-manager can call as the first step of their Reconcile.
+package finalizers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Getter returns the latest version of the named object, so a finalizer patch can be built
+// against a fresh resourceVersion instead of a possibly-stale cached copy.
+type Getter func(name string) (metav1.Object, error)
+
+// Patcher sends a patch of the given type for the named object.
+type Patcher func(name string, patchType types.PatchType, data []byte) error
+
+type finalizerPatch struct {
+	Metadata finalizerPatchMetadata `json:"metadata"`
+}
+
+type finalizerPatchMetadata struct {
+	Finalizers      []string `json:"finalizers"`
+	ResourceVersion string   `json:"resourceVersion"`
+}
+
+// EnsureFinalizer adds finalizer to the named object if it isn't already present. It always
+// re-GETs the object first so the patch can carry a resourceVersion precondition, and issues a
+// JSON merge patch rather than an Update so it cannot clobber a concurrent status write. It
+// reports whether the finalizer was newly added, and is safe to call unconditionally as the
+// first step of Reconcile - a finalizer stripped by a user mid-lifecycle is simply re-added.
+func EnsureFinalizer(get Getter, patch Patcher, name, finalizer string) (bool, error) {
+	obj, err := get(name)
+	if err != nil {
+		return false, err
+	}
+
+	if sets.NewString(obj.GetFinalizers()...).Has(finalizer) {
+		return false, nil
+	}
+
+	if err := sendFinalizerPatch(patch, name, obj, append(obj.GetFinalizers(), finalizer)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveFinalizer removes finalizer from the named object if present, following the same
+// GET-then-patch pattern as EnsureFinalizer. It reports whether the finalizer was removed.
+func RemoveFinalizer(get Getter, patch Patcher, name, finalizer string) (bool, error) {
+	obj, err := get(name)
+	if err != nil {
+		return false, err
+	}
+
+	remaining := sets.NewString(obj.GetFinalizers()...)
+	if !remaining.Has(finalizer) {
+		return false, nil
+	}
+	remaining.Delete(finalizer)
+
+	if err := sendFinalizerPatch(patch, name, obj, remaining.List()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func sendFinalizerPatch(patch Patcher, name string, obj metav1.Object, finalizers []string) error {
+	data, err := json.Marshal(finalizerPatch{
+		Metadata: finalizerPatchMetadata{
+			Finalizers:      finalizers,
+			ResourceVersion: obj.GetResourceVersion(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal finalizer patch for %q: %v", name, err)
+	}
+
+	return patch(name, types.MergePatchType, data)
+}